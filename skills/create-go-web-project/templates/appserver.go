@@ -0,0 +1,77 @@
+package main
+
+import (
+{{#if POSTGRES}}
+    "gorm.io/gorm"
+{{else}}
+    "database/sql"
+{{/if}}
+    "net/http"
+{{#if FEEDS}}
+
+    "{{PROJECT_NAME}}/internal/feeds"
+{{/if}}
+{{#if CEL}}
+
+    "{{PROJECT_NAME}}/internal/policy"
+{{/if}}
+{{#if OBSERVABILITY}}
+
+    "go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+    "{{PROJECT_NAME}}/internal/metrics"
+{{/if}}
+)
+
+{{#if POSTGRES}}
+// newAppHandler builds the HTTP handler for {{PROJECT_NAME}}'s public web
+// server, wiring in the feeds endpoints when FEEDS is enabled and requiring
+// the "http_access" CEL policy when CEL is enabled.
+func newAppHandler(db *gorm.DB) (http.Handler, error) {
+{{else}}
+// newAppHandler builds the HTTP handler for {{PROJECT_NAME}}'s public web
+// server, wiring in the feeds endpoints when FEEDS is enabled and requiring
+// the "http_access" CEL policy when CEL is enabled.
+func newAppHandler(db *sql.DB) (http.Handler, error) {
+{{/if}}
+    mux := http.NewServeMux()
+
+{{#if FEEDS}}
+{{#if POSTGRES}}
+    source := feeds.NewGormSource(db)
+{{else}}
+    source := feeds.NewSQLSource(db)
+{{/if}}
+    publisher := feeds.NewPublisher("{{PROJECT_NAME}}", "/", "Latest updates from {{PROJECT_NAME}}", "{{PROJECT_NAME}}", source)
+    for path, handler := range map[string]http.HandlerFunc{
+        "/feed.rss":  publisher.RSSHandler(),
+        "/feed.atom": publisher.AtomHandler(),
+        "/feed.json": publisher.JSONHandler(),
+    } {
+{{#if OBSERVABILITY}}
+        mux.Handle(path, metrics.InstrumentHandler(path, handler))
+{{else}}
+        mux.Handle(path, handler)
+{{/if}}
+    }
+{{/if}}
+
+    var handler http.Handler = mux
+
+{{#if CEL}}
+    compiler, err := policy.NewCompiler()
+    if err != nil {
+        return nil, err
+    }
+    if err := compiler.LoadDir("policies"); err != nil {
+        return nil, err
+    }
+    handler = policy.RequireHTTPAccess(compiler, handler)
+{{/if}}
+
+{{#if OBSERVABILITY}}
+    handler = otelhttp.NewHandler(handler, "{{PROJECT_NAME}}")
+{{/if}}
+
+    return handler, nil
+}
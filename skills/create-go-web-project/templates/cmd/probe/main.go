@@ -0,0 +1,33 @@
+{{#if OBSERVABILITY}}
+// Command probe is a standalone health check binary suitable for container
+// HEALTHCHECK directives: it exits 0 when the service's /metrics endpoint
+// responds with 200 OK, non-zero otherwise.
+package main
+
+import (
+    "flag"
+    "fmt"
+    "net/http"
+    "os"
+    "time"
+)
+
+func main() {
+    addr := flag.String("addr", "http://localhost:9090/metrics", "URL to probe")
+    timeout := flag.Duration("timeout", 2*time.Second, "request timeout")
+    flag.Parse()
+
+    client := &http.Client{Timeout: *timeout}
+    resp, err := client.Get(*addr)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "probe: %v\n", err)
+        os.Exit(1)
+    }
+    defer resp.Body.Close()
+
+    if resp.StatusCode != http.StatusOK {
+        fmt.Fprintf(os.Stderr, "probe: unexpected status %d\n", resp.StatusCode)
+        os.Exit(1)
+    }
+}
+{{/if}}
@@ -0,0 +1,109 @@
+{{#if GITOPS}}
+// Command project is a small CLI for operations on the project's own
+// generated artifacts. Today it supports "sync", which pushes them to
+// whichever host GIT_PROVIDER selects.
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "io/fs"
+    "os"
+    "path/filepath"
+
+    "{{PROJECT_NAME}}/internal/gitops"
+)
+
+func main() {
+    if len(os.Args) < 2 {
+        fmt.Fprintln(os.Stderr, "usage: project sync --owner OWNER --repo REPO --branch BRANCH")
+        os.Exit(2)
+    }
+
+    switch os.Args[1] {
+    case "sync":
+        runSync(os.Args[2:])
+    default:
+        fmt.Fprintf(os.Stderr, "unknown subcommand %q\n", os.Args[1])
+        os.Exit(2)
+    }
+}
+
+func runSync(args []string) {
+    flags := flag.NewFlagSet("sync", flag.ExitOnError)
+    owner := flags.String("owner", "", "repository owner/org")
+    repo := flags.String("repo", "", "repository name")
+    branch := flags.String("branch", "sync", "branch to commit generated artifacts to")
+    dir := flags.String("dir", ".", "directory of generated artifacts to push")
+    _ = flags.Parse(args)
+
+    provider, err := gitops.NewFromEnv()
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "sync: %v\n", err)
+        os.Exit(1)
+    }
+
+    files, err := collectArtifacts(*dir)
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "sync: %v\n", err)
+        os.Exit(1)
+    }
+
+    ctx := context.Background()
+    if err := provider.EnsureRepo(ctx, *owner, *repo); err != nil {
+        fmt.Fprintf(os.Stderr, "sync: %v\n", err)
+        os.Exit(1)
+    }
+
+    if err := provider.CommitFiles(ctx, *owner, *repo, *branch, "sync generated artifacts", files); err != nil {
+        fmt.Fprintf(os.Stderr, "sync: %v\n", err)
+        os.Exit(1)
+    }
+
+    url, err := provider.OpenPR(ctx, *owner, *repo, "main", *branch, "Sync generated artifacts", "")
+    if err != nil {
+        fmt.Fprintf(os.Stderr, "sync: %v\n", err)
+        os.Exit(1)
+    }
+
+    fmt.Println(url)
+}
+
+// collectArtifacts walks dir and reads every regular file (skipping .git)
+// into a path -> content map keyed relative to dir, suitable for
+// gitops.Provider.CommitFiles.
+func collectArtifacts(dir string) (map[string]string, error) {
+    files := make(map[string]string)
+
+    err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+        if err != nil {
+            return err
+        }
+        if d.IsDir() {
+            if d.Name() == ".git" {
+                return filepath.SkipDir
+            }
+            return nil
+        }
+
+        rel, err := filepath.Rel(dir, path)
+        if err != nil {
+            return err
+        }
+
+        content, err := os.ReadFile(path)
+        if err != nil {
+            return err
+        }
+
+        files[rel] = string(content)
+        return nil
+    })
+    if err != nil {
+        return nil, fmt.Errorf("collect artifacts under %s: %w", dir, err)
+    }
+
+    return files, nil
+}
+{{/if}}
@@ -0,0 +1,117 @@
+package main
+
+import (
+{{#if POSTGRES}}
+{{#if OBSERVABILITY}}
+    "context"
+{{/if}}
+    "fmt"
+    "time"
+
+{{#if POSTGRES_MIGRATE}}
+    "github.com/golang-migrate/migrate/v4"
+    _ "github.com/golang-migrate/migrate/v4/database/postgres"
+    _ "github.com/golang-migrate/migrate/v4/source/file"
+{{/if}}
+    "gorm.io/driver/postgres"
+    "gorm.io/gorm"
+{{else}}
+{{#if OBSERVABILITY}}
+    "context"
+{{/if}}
+    "database/sql"
+    "embed"
+    "fmt"
+
+    "github.com/pressly/goose/v3"
+    _ "modernc.org/sqlite"
+{{/if}}
+{{#if OBSERVABILITY}}
+
+    "go.opentelemetry.io/otel"
+
+    "{{PROJECT_NAME}}/internal/metrics"
+{{/if}}
+)
+
+{{#if POSTGRES}}
+// newDB opens a connection to the Postgres database at databaseURL and
+// brings the schema up to date, either via GORM's AutoMigrate or, when
+// POSTGRES_MIGRATE is enabled, by running the SQL files under
+// migrations/postgres with golang-migrate.
+func newDB(databaseURL string) (*gorm.DB, error) {
+{{#if OBSERVABILITY}}
+    _, span := otel.Tracer("{{PROJECT_NAME}}/db").Start(context.Background(), "open-postgres")
+    defer span.End()
+{{/if}}
+
+    db, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
+    if err != nil {
+        return nil, fmt.Errorf("open postgres: %w", err)
+    }
+
+    sqlDB, err := db.DB()
+    if err != nil {
+        return nil, fmt.Errorf("get sql.DB: %w", err)
+    }
+    sqlDB.SetMaxOpenConns(25)
+    sqlDB.SetMaxIdleConns(25)
+    sqlDB.SetConnMaxLifetime(5 * time.Minute)
+
+{{#if OBSERVABILITY}}
+    metrics.RegisterDBStatsCollector("postgres", sqlDB)
+{{/if}}
+
+{{#if POSTGRES_MIGRATE}}
+    m, err := migrate.New("file://migrations/postgres", databaseURL)
+    if err != nil {
+        return nil, fmt.Errorf("load postgres migrations: %w", err)
+    }
+    if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+        return nil, fmt.Errorf("run postgres migrations: %w", err)
+    }
+{{else}}
+    if err := db.AutoMigrate(
+        &User{},
+{{#if FEEDS}}
+        &FeedItem{},
+{{/if}}
+    ); err != nil {
+        return nil, fmt.Errorf("automigrate: %w", err)
+    }
+{{/if}}
+
+    return db, nil
+}
+{{else}}
+//go:embed migrations/*.sql
+var embedMigrations embed.FS
+
+// newDB opens the SQLite database at dataSourceName and applies any pending
+// goose migrations embedded in the binary.
+func newDB(dataSourceName string) (*sql.DB, error) {
+{{#if OBSERVABILITY}}
+    _, span := otel.Tracer("{{PROJECT_NAME}}/db").Start(context.Background(), "open-sqlite")
+    defer span.End()
+{{/if}}
+
+    db, err := sql.Open("sqlite", dataSourceName)
+    if err != nil {
+        return nil, fmt.Errorf("open sqlite: %w", err)
+    }
+
+    goose.SetBaseFS(embedMigrations)
+    if err := goose.SetDialect("sqlite3"); err != nil {
+        return nil, fmt.Errorf("set goose dialect: %w", err)
+    }
+    if err := goose.Up(db, "migrations"); err != nil {
+        return nil, fmt.Errorf("run migrations: %w", err)
+    }
+
+{{#if OBSERVABILITY}}
+    metrics.RegisterDBStatsCollector("sqlite", db)
+{{/if}}
+
+    return db, nil
+}
+{{/if}}
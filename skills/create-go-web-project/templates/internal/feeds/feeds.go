@@ -0,0 +1,42 @@
+{{#if FEEDS}}
+// Package feeds serves RSS, Atom, and JSON feeds backed by a pluggable
+// ItemSource, defaulting to the project's "feed_items" table.
+package feeds
+
+import (
+    "context"
+    "time"
+)
+
+// Item is one entry in a feed.
+type Item struct {
+    ID          string
+    Title       string
+    Link        string
+    Description string
+    Author      string
+    Published   time.Time
+    Updated     time.Time
+    Private     bool
+}
+
+// ItemSource yields feed items newest-first. Implementations may back this
+// with SQL, an object store, or anything else.
+type ItemSource interface {
+    ListItems(ctx context.Context, limit int) ([]Item, error)
+}
+
+// Publisher renders Items from a Source into RSS/Atom/JSON feed documents.
+type Publisher struct {
+    Title       string
+    Link        string
+    Description string
+    Author      string
+    Source      ItemSource
+}
+
+// NewPublisher builds a Publisher for the given feed metadata and source.
+func NewPublisher(title, link, description, author string, source ItemSource) *Publisher {
+    return &Publisher{Title: title, Link: link, Description: description, Author: author, Source: source}
+}
+{{/if}}
@@ -0,0 +1,81 @@
+{{#if FEEDS}}
+package feeds
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "net/http"
+    "time"
+
+    "github.com/gorilla/feeds"
+)
+
+const itemLimit = 50
+
+// Handler serves /feed.rss, /feed.atom, and /feed.json for p, using
+// renderer to produce the body for each format.
+func (p *Publisher) Handler(renderer func(*feeds.Feed) (string, error)) http.HandlerFunc {
+    return func(w http.ResponseWriter, r *http.Request) {
+        items, err := p.Source.ListItems(r.Context(), itemLimit)
+        if err != nil {
+            http.Error(w, "failed to load feed items", http.StatusInternalServerError)
+            return
+        }
+
+        feed := &feeds.Feed{
+            Title:       p.Title,
+            Link:        &feeds.Link{Href: p.Link},
+            Description: p.Description,
+            Author:      &feeds.Author{Name: p.Author},
+            Created:     time.Now(),
+        }
+        for _, item := range items {
+            feed.Items = append(feed.Items, &feeds.Item{
+                Id:          item.ID,
+                Title:       item.Title,
+                Link:        &feeds.Link{Href: item.Link},
+                Description: item.Description,
+                Author:      &feeds.Author{Name: item.Author},
+                Created:     item.Published,
+                Updated:     item.Updated,
+            })
+        }
+
+        body, err := renderer(feed)
+        if err != nil {
+            http.Error(w, "failed to render feed", http.StatusInternalServerError)
+            return
+        }
+
+        etag := etagFor(body)
+        w.Header().Set("ETag", etag)
+        if match := r.Header.Get("If-None-Match"); match == etag {
+            w.WriteHeader(http.StatusNotModified)
+            return
+        }
+
+        _, _ = w.Write([]byte(body))
+    }
+}
+
+// RSSHandler serves the feed as RSS 2.0.
+func (p *Publisher) RSSHandler() http.HandlerFunc {
+    return p.Handler(func(f *feeds.Feed) (string, error) { return f.ToRss() })
+}
+
+// AtomHandler serves the feed as Atom.
+func (p *Publisher) AtomHandler() http.HandlerFunc {
+    return p.Handler(func(f *feeds.Feed) (string, error) { return f.ToAtom() })
+}
+
+// JSONHandler serves the feed as JSON Feed.
+func (p *Publisher) JSONHandler() http.HandlerFunc {
+    return p.Handler(func(f *feeds.Feed) (string, error) { return f.ToJSON() })
+}
+
+func etagFor(body string) string {
+    sum := sha256.Sum256([]byte(body))
+    return fmt.Sprintf(`"%s"`, hex.EncodeToString(sum[:8]))
+}
+{{/if}}
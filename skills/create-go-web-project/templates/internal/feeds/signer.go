@@ -0,0 +1,47 @@
+{{#if FEEDS}}
+{{#if OAUTH_GOOGLE}}
+package feeds
+
+import (
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "net/http"
+)
+
+// Signer produces and verifies per-user tokens so private feeds can be
+// fetched by URL (feed readers can't do OAuth redirects) without exposing
+// the underlying secret.
+type Signer struct {
+    secret []byte
+}
+
+// NewSigner builds a Signer from secret, typically an app-wide key kept
+// outside source control.
+func NewSigner(secret string) *Signer {
+    return &Signer{secret: []byte(secret)}
+}
+
+// TokenFor returns the signed token to embed in a private feed's URL for
+// userID.
+func (s *Signer) TokenFor(userID string) string {
+    mac := hmac.New(sha256.New, s.secret)
+    mac.Write([]byte(userID))
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+// RequirePrivateToken rejects requests whose "token" query parameter doesn't
+// match TokenFor(userID).
+func (s *Signer) RequirePrivateToken(userID string, next http.HandlerFunc) http.HandlerFunc {
+    want := s.TokenFor(userID)
+    return func(w http.ResponseWriter, r *http.Request) {
+        got := r.URL.Query().Get("token")
+        if !hmac.Equal([]byte(got), []byte(want)) {
+            http.Error(w, "forbidden", http.StatusForbidden)
+            return
+        }
+        next(w, r)
+    }
+}
+{{/if}}
+{{/if}}
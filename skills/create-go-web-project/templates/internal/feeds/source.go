@@ -0,0 +1,107 @@
+{{#if FEEDS}}
+package feeds
+
+import (
+    "context"
+{{#if POSTGRES}}
+    "fmt"
+    "time"
+
+    "gorm.io/gorm"
+{{else}}
+    "database/sql"
+    "fmt"
+{{/if}}
+)
+
+{{#if POSTGRES}}
+// gormItem mirrors the generated project's FeedItem model so this package
+// doesn't have to import the root "main" package.
+type gormItem struct {
+    ID          string
+    Title       string
+    Link        string
+    Description string
+    Author      string
+    Private     bool
+    Published   time.Time
+    Updated     time.Time
+}
+
+// GormSource reads feed_items through GORM, the default ItemSource when
+// POSTGRES is enabled.
+type GormSource struct {
+    db *gorm.DB
+}
+
+// NewGormSource builds an ItemSource backed by db's "feed_items" table.
+func NewGormSource(db *gorm.DB) *GormSource {
+    return &GormSource{db: db}
+}
+
+func (s *GormSource) ListItems(ctx context.Context, limit int) ([]Item, error) {
+    var rows []gormItem
+    if err := s.db.WithContext(ctx).
+        Table("feed_items").
+        Where("private = ?", false).
+        Order("published desc").
+        Limit(limit).
+        Find(&rows).Error; err != nil {
+        return nil, fmt.Errorf("list feed items: %w", err)
+    }
+
+    items := make([]Item, 0, len(rows))
+    for _, r := range rows {
+        items = append(items, Item{
+            ID:          r.ID,
+            Title:       r.Title,
+            Link:        r.Link,
+            Description: r.Description,
+            Author:      r.Author,
+            Private:     r.Private,
+            Published:   r.Published,
+            Updated:     r.Updated,
+        })
+    }
+    return items, nil
+}
+{{else}}
+// SQLSource reads feed_items with plain database/sql queries, the default
+// ItemSource for the SQLite backend.
+type SQLSource struct {
+    db *sql.DB
+}
+
+// NewSQLSource builds an ItemSource backed by db's "feed_items" table.
+func NewSQLSource(db *sql.DB) *SQLSource {
+    return &SQLSource{db: db}
+}
+
+func (s *SQLSource) ListItems(ctx context.Context, limit int) ([]Item, error) {
+    rows, err := s.db.QueryContext(ctx, `
+        SELECT id, title, link, description, author, private, published, updated
+        FROM feed_items
+        WHERE private = FALSE
+        ORDER BY published DESC
+        LIMIT ?`, limit)
+    if err != nil {
+        return nil, fmt.Errorf("list feed items: %w", err)
+    }
+    defer rows.Close()
+
+    var items []Item
+    for rows.Next() {
+        var item Item
+        if err := rows.Scan(&item.ID, &item.Title, &item.Link, &item.Description, &item.Author, &item.Private, &item.Published, &item.Updated); err != nil {
+            return nil, fmt.Errorf("scan feed item: %w", err)
+        }
+        items = append(items, item)
+    }
+    if err := rows.Err(); err != nil {
+        return nil, fmt.Errorf("list feed items: %w", err)
+    }
+
+    return items, nil
+}
+{{/if}}
+{{/if}}
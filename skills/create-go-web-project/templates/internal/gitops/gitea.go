@@ -0,0 +1,18 @@
+{{#if GITOPS}}
+package gitops
+
+import (
+    "github.com/fluxcd/go-git-providers/gitea"
+    "github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// NewGiteaProvider authenticates to a self-hosted Gitea instance at
+// baseURL with a personal access token.
+func NewGiteaProvider(token, baseURL string) (Provider, error) {
+    client, err := gitea.NewClient(baseURL, gitprovider.WithOAuth2Token(token))
+    if err != nil {
+        return nil, err
+    }
+    return &clientProvider{client: client}, nil
+}
+{{/if}}
@@ -0,0 +1,123 @@
+{{#if GITOPS}}
+package gitops
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/fluxcd/go-git-providers/github"
+    "github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// clientProvider implements Provider on top of any gitprovider.Client,
+// since GitHub, Gitea, and GitLab all expose the same client surface.
+type clientProvider struct {
+    client gitprovider.Client
+}
+
+// NewGitHubProvider authenticates to github.com with a personal access
+// token.
+func NewGitHubProvider(token string) (Provider, error) {
+    client, err := github.NewClient(gitprovider.WithOAuth2Token(token))
+    if err != nil {
+        return nil, fmt.Errorf("github client: %w", err)
+    }
+    return &clientProvider{client: client}, nil
+}
+
+func (p *clientProvider) repoRef(owner, repo string) gitprovider.OrgRepositoryRef {
+    return gitprovider.OrgRepositoryRef{
+        OrganizationRef: gitprovider.OrganizationRef{Domain: p.client.SupportedDomain(), Organization: owner},
+        RepositoryName:  repo,
+    }
+}
+
+func (p *clientProvider) EnsureRepo(ctx context.Context, owner, repo string) error {
+    ref := p.repoRef(owner, repo)
+    if _, err := p.client.OrgRepositories().Get(ctx, ref); err == nil {
+        return nil
+    }
+    _, err := p.client.OrgRepositories().Create(ctx, ref, gitprovider.RepositoryInfo{})
+    if err != nil {
+        return fmt.Errorf("create repo %s/%s: %w", owner, repo, err)
+    }
+    return nil
+}
+
+func (p *clientProvider) CommitFiles(ctx context.Context, owner, repo, branch, message string, files map[string]string) error {
+    r, err := p.client.OrgRepositories().Get(ctx, p.repoRef(owner, repo))
+    if err != nil {
+        return fmt.Errorf("get repo %s/%s: %w", owner, repo, err)
+    }
+
+    if err := ensureBranch(ctx, r, branch); err != nil {
+        return fmt.Errorf("ensure branch %s/%s@%s: %w", owner, repo, branch, err)
+    }
+
+    commitFiles := make([]gitprovider.CommitFile, 0, len(files))
+    for path, content := range files {
+        path, content := path, content
+        commitFiles = append(commitFiles, gitprovider.CommitFile{Path: &path, Content: &content})
+    }
+
+    if _, err := r.Commits().Create(ctx, branch, message, commitFiles); err != nil {
+        return fmt.Errorf("commit to %s/%s@%s: %w", owner, repo, branch, err)
+    }
+    return nil
+}
+
+// ensureBranch creates branch from the repository's default branch if it
+// doesn't already exist.
+func ensureBranch(ctx context.Context, r gitprovider.OrgRepository, branch string) error {
+    existing, err := r.Branches().List(ctx)
+    if err != nil {
+        return fmt.Errorf("list branches: %w", err)
+    }
+    for _, b := range existing {
+        if b.Name == branch {
+            return nil
+        }
+    }
+
+    defaultBranch := "main"
+    if db := r.Get().DefaultBranch; db != nil {
+        defaultBranch = *db
+    }
+
+    if err := r.Branches().Create(ctx, branch, defaultBranch); err != nil {
+        return fmt.Errorf("create branch %s from %s: %w", branch, defaultBranch, err)
+    }
+    return nil
+}
+
+func (p *clientProvider) OpenPR(ctx context.Context, owner, repo, base, head, title, body string) (string, error) {
+    r, err := p.client.OrgRepositories().Get(ctx, p.repoRef(owner, repo))
+    if err != nil {
+        return "", fmt.Errorf("get repo %s/%s: %w", owner, repo, err)
+    }
+
+    pr, err := r.PullRequests().Create(ctx, title, head, base, body)
+    if err != nil {
+        return "", fmt.Errorf("open PR %s/%s %s->%s: %w", owner, repo, head, base, err)
+    }
+    return pr.Get().WebURL, nil
+}
+
+func (p *clientProvider) ListBranches(ctx context.Context, owner, repo string) ([]string, error) {
+    r, err := p.client.OrgRepositories().Get(ctx, p.repoRef(owner, repo))
+    if err != nil {
+        return nil, fmt.Errorf("get repo %s/%s: %w", owner, repo, err)
+    }
+
+    branches, err := r.Branches().List(ctx)
+    if err != nil {
+        return nil, fmt.Errorf("list branches %s/%s: %w", owner, repo, err)
+    }
+
+    names := make([]string, 0, len(branches))
+    for _, b := range branches {
+        names = append(names, b.Name)
+    }
+    return names, nil
+}
+{{/if}}
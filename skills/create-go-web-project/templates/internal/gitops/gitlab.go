@@ -0,0 +1,18 @@
+{{#if GITOPS}}
+package gitops
+
+import (
+    "github.com/fluxcd/go-git-providers/gitlab"
+    "github.com/fluxcd/go-git-providers/gitprovider"
+)
+
+// NewGitLabProvider authenticates to gitlab.com with a personal access
+// token.
+func NewGitLabProvider(token string) (Provider, error) {
+    client, err := gitlab.NewClient(token, "", gitprovider.WithOAuth2Token(token))
+    if err != nil {
+        return nil, err
+    }
+    return &clientProvider{client: client}, nil
+}
+{{/if}}
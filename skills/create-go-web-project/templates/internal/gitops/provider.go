@@ -0,0 +1,44 @@
+{{#if GITOPS}}
+// Package gitops unifies GitHub, Gitea, and GitLab behind one interface
+// (backed by fluxcd/go-git-providers) so generated artifacts can be pushed
+// to whichever host a deployment uses.
+package gitops
+
+import (
+    "context"
+    "fmt"
+    "os"
+)
+
+// Provider pushes generated project artifacts to a remote git host and opens
+// pull requests for review.
+type Provider interface {
+    // EnsureRepo creates the named repository if it doesn't already exist.
+    EnsureRepo(ctx context.Context, owner, repo string) error
+
+    // CommitFiles commits files (path -> content) to branch, creating it
+    // from the repository's default branch if it doesn't exist.
+    CommitFiles(ctx context.Context, owner, repo, branch, message string, files map[string]string) error
+
+    // OpenPR opens a pull request from head into base and returns its URL.
+    OpenPR(ctx context.Context, owner, repo, base, head, title, body string) (string, error)
+
+    // ListBranches lists the branches of repo.
+    ListBranches(ctx context.Context, owner, repo string) ([]string, error)
+}
+
+// NewFromEnv builds a Provider selected by the GIT_PROVIDER environment
+// variable ("github", "gitea", or "gitlab").
+func NewFromEnv() (Provider, error) {
+    switch p := os.Getenv("GIT_PROVIDER"); p {
+    case "github", "":
+        return NewGitHubProvider(os.Getenv("GITHUB_TOKEN"))
+    case "gitea":
+        return NewGiteaProvider(os.Getenv("GITEA_TOKEN"), os.Getenv("GITEA_BASE_URL"))
+    case "gitlab":
+        return NewGitLabProvider(os.Getenv("GITLAB_TOKEN"))
+    default:
+        return nil, fmt.Errorf("unknown GIT_PROVIDER %q", p)
+    }
+}
+{{/if}}
@@ -0,0 +1,52 @@
+{{#if LOGGING}}
+// Package logging provides the project's zerolog setup: JSON output in
+// production, a human-readable console writer in dev, and a
+// context-scoped logger carrying a per-request ID.
+package logging
+
+import (
+    "context"
+    "os"
+    "time"
+
+    "github.com/rs/zerolog"
+    "github.com/rs/zerolog/log"
+)
+
+type ctxKey struct{}
+
+// New builds the base logger for env ("dev" or "prod"), writing JSON in
+// prod and a colorized console format in dev. Hot paths can pass a
+// sampleEvery > 1 to log only one in every sampleEvery events.
+func New(env string, sampleEvery int) zerolog.Logger {
+    var writer = os.Stdout
+    var logger zerolog.Logger
+
+    if env == "prod" {
+        logger = zerolog.New(writer)
+    } else {
+        logger = zerolog.New(zerolog.ConsoleWriter{Out: writer, TimeFormat: time.RFC3339})
+    }
+    logger = logger.With().Timestamp().Logger()
+
+    if sampleEvery > 1 {
+        logger = logger.Sample(&zerolog.BasicSampler{N: uint32(sampleEvery)})
+    }
+
+    return logger
+}
+
+// WithContext returns a context carrying logger, retrievable via FromContext.
+func WithContext(ctx context.Context, logger zerolog.Logger) context.Context {
+    return context.WithValue(ctx, ctxKey{}, logger)
+}
+
+// FromContext returns the logger attached to ctx, or zerolog's global
+// logger if none was attached.
+func FromContext(ctx context.Context) zerolog.Logger {
+    if logger, ok := ctx.Value(ctxKey{}).(zerolog.Logger); ok {
+        return logger
+    }
+    return log.Logger
+}
+{{/if}}
@@ -0,0 +1,42 @@
+{{#if LOGGING}}
+package logging
+
+import (
+    "net/http"
+
+    "github.com/google/uuid"
+    "github.com/rs/zerolog"
+)
+
+// HTTPMiddleware stamps every request with a request_id and attaches a
+// logger carrying it to the request's context.
+func HTTPMiddleware(base zerolog.Logger) func(http.Handler) http.Handler {
+    return func(next http.Handler) http.Handler {
+        return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            requestID := uuid.NewString()
+            logger := base.With().Str("request_id", requestID).Logger()
+            w.Header().Set("X-Request-Id", requestID)
+            next.ServeHTTP(w, r.WithContext(WithContext(r.Context(), logger)))
+        })
+    }
+}
+
+{{#if TELEGRAM}}
+// TelegramRequestID stamps an incoming Telegram update with a request_id and
+// returns a logger carrying it, for use alongside the bot's context.
+func TelegramRequestID(base zerolog.Logger) (string, zerolog.Logger) {
+    requestID := uuid.NewString()
+    return requestID, base.With().Str("request_id", requestID).Logger()
+}
+{{/if}}
+
+{{#if WEBSOCKET}}
+// WebSocketConnID stamps a newly accepted WebSocket connection with a
+// request_id and returns a logger carrying it for the life of the
+// connection.
+func WebSocketConnID(base zerolog.Logger) (string, zerolog.Logger) {
+    requestID := uuid.NewString()
+    return requestID, base.With().Str("request_id", requestID).Logger()
+}
+{{/if}}
+{{/if}}
@@ -0,0 +1,90 @@
+{{#if OBSERVABILITY}}
+// Package metrics holds the Prometheus collectors shared across the
+// generated project's subsystems (HTTP, database, object storage,
+// Telegram/WebSocket) so they all register against one registry.
+package metrics
+
+import (
+    "database/sql"
+    "net/http"
+    "strconv"
+    "time"
+
+    "github.com/prometheus/client_golang/prometheus"
+    "github.com/prometheus/client_golang/prometheus/promauto"
+    "github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+    httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+        Name: "http_request_duration_seconds",
+        Help: "Latency of HTTP requests by route and status code.",
+    }, []string{"route", "status"})
+
+    storageOpsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "storage_operations_total",
+        Help: "Count of object storage operations by name and outcome.",
+    }, []string{"operation", "outcome"})
+
+    messagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+        Name: "messages_total",
+        Help: "Count of inbound/outbound messages by channel and direction.",
+    }, []string{"channel", "direction"})
+)
+
+// InstrumentHandler wraps h with a latency/status histogram recorded under route.
+func InstrumentHandler(route string, h http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        start := time.Now()
+        sw := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+        h.ServeHTTP(sw, r)
+        httpRequestDuration.WithLabelValues(route, strconv.Itoa(sw.status)).Observe(time.Since(start).Seconds())
+    })
+}
+
+type statusRecorder struct {
+    http.ResponseWriter
+    status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+    s.status = status
+    s.ResponseWriter.WriteHeader(status)
+}
+
+// RecordStorageOp increments the storage operation counter for op, labeling
+// the outcome as "error" when err is non-nil and "ok" otherwise.
+func RecordStorageOp(op string, err error) {
+    outcome := "ok"
+    if err != nil {
+        outcome = "error"
+    }
+    storageOpsTotal.WithLabelValues(op, outcome).Inc()
+}
+
+// RecordMessage increments the message counter for channel (e.g. "telegram",
+// "websocket") and direction ("inbound" or "outbound").
+func RecordMessage(channel, direction string) {
+    messagesTotal.WithLabelValues(channel, direction).Inc()
+}
+
+// RegisterDBStatsCollector exposes db.Stats() as Prometheus gauges under the
+// given name (e.g. "sqlite", "postgres").
+func RegisterDBStatsCollector(name string, db *sql.DB) {
+    prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+        Name:        "db_open_connections",
+        Help:        "Number of established connections to the database.",
+        ConstLabels: prometheus.Labels{"db": name},
+    }, func() float64 { return float64(db.Stats().OpenConnections) }))
+    prometheus.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+        Name:        "db_in_use_connections",
+        Help:        "Number of connections currently in use.",
+        ConstLabels: prometheus.Labels{"db": name},
+    }, func() float64 { return float64(db.Stats().InUse) }))
+}
+
+// Handler serves the /metrics endpoint for Prometheus scraping.
+func Handler() http.Handler {
+    return promhttp.Handler()
+}
+{{/if}}
@@ -0,0 +1,30 @@
+{{#if CEL}}
+package policy
+
+import (
+    "net/http"
+
+    "github.com/google/cel-go/common/types"
+)
+
+// RequireHTTPAccess rejects requests with 403 unless the "http_access"
+// policy evaluates to true for the request.
+func RequireHTTPAccess(c *Compiler, next http.Handler) http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        input := map[string]any{
+            "request": map[string]any{
+                "method": r.Method,
+                "path":   r.URL.Path,
+            },
+        }
+
+        out, err := c.EvaluateNamed(r.Context(), "http_access", input)
+        if err != nil || out != types.True {
+            http.Error(w, "forbidden", http.StatusForbidden)
+            return
+        }
+
+        next.ServeHTTP(w, r)
+    })
+}
+{{/if}}
@@ -0,0 +1,126 @@
+{{#if CEL}}
+// Package policy evaluates CEL expressions against request-shaped input so
+// authorization rules can change without a recompile.
+package policy
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "path/filepath"
+
+    "github.com/google/cel-go/cel"
+    "github.com/google/cel-go/common/types/ref"
+)
+
+// Compiler compiles and caches CEL programs for a fixed environment. The
+// environment declares "user" and "request" as dynamic maps so callers can
+// pass the generated project's domain types through input without the
+// policy package depending on them directly.
+type Compiler struct {
+    env      *cel.Env
+    programs map[string]cel.Program
+}
+
+// NewCompiler builds a Compiler with the project's domain variables
+// registered.
+func NewCompiler() (*Compiler, error) {
+    env, err := cel.NewEnv(
+        cel.Variable("user", cel.DynType),
+        cel.Variable("request", cel.DynType),
+    )
+    if err != nil {
+        return nil, fmt.Errorf("build cel env: %w", err)
+    }
+    return &Compiler{env: env, programs: make(map[string]cel.Program)}, nil
+}
+
+// LoadDir compiles every *.cel file in dir, keyed by file name without the
+// extension (e.g. policies/http_access.cel becomes "http_access").
+func (c *Compiler) LoadDir(dir string) error {
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return fmt.Errorf("read policy dir %s: %w", dir, err)
+    }
+    for _, entry := range entries {
+        if entry.IsDir() || filepath.Ext(entry.Name()) != ".cel" {
+            continue
+        }
+        src, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+        if err != nil {
+            return fmt.Errorf("read policy %s: %w", entry.Name(), err)
+        }
+        name := entry.Name()[:len(entry.Name())-len(".cel")]
+        if err := c.Compile(name, string(src)); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// PolicyRow is one row of a "policies" table (name, expr columns).
+type PolicyRow struct {
+    Name string
+    Expr string
+}
+
+// LoadRows compiles each row queried from a "policies" table, keyed by its
+// Name. Callers own the actual SQL/GORM query; this just compiles the
+// results.
+func (c *Compiler) LoadRows(rows []PolicyRow) error {
+    for _, row := range rows {
+        if err := c.Compile(row.Name, row.Expr); err != nil {
+            return err
+        }
+    }
+    return nil
+}
+
+// Compile parses and compiles expr, caching the resulting program under name
+// for later EvaluateNamed calls.
+func (c *Compiler) Compile(name, expr string) error {
+    ast, issues := c.env.Compile(expr)
+    if issues != nil && issues.Err() != nil {
+        return fmt.Errorf("compile policy %s: %w", name, issues.Err())
+    }
+    prg, err := c.env.Program(ast)
+    if err != nil {
+        return fmt.Errorf("build program %s: %w", name, err)
+    }
+    c.programs[name] = prg
+    return nil
+}
+
+// Evaluate compiles expr and runs it against input. Unlike EvaluateNamed, it
+// does not consult or populate the Compiler's cache, so it suits one-off
+// rules that don't come from LoadDir/LoadRows.
+func (c *Compiler) Evaluate(ctx context.Context, expr string, input map[string]any) (ref.Val, error) {
+    ast, issues := c.env.Compile(expr)
+    if issues != nil && issues.Err() != nil {
+        return nil, fmt.Errorf("compile expr %q: %w", expr, issues.Err())
+    }
+    prg, err := c.env.Program(ast)
+    if err != nil {
+        return nil, fmt.Errorf("build program for %q: %w", expr, err)
+    }
+    out, _, err := prg.ContextEval(ctx, input)
+    if err != nil {
+        return nil, fmt.Errorf("evaluate expr %q: %w", expr, err)
+    }
+    return out, nil
+}
+
+// EvaluateNamed runs the named policy, pre-loaded via LoadDir/Compile/
+// LoadRows, against input.
+func (c *Compiler) EvaluateNamed(ctx context.Context, name string, input map[string]any) (ref.Val, error) {
+    prg, ok := c.programs[name]
+    if !ok {
+        return nil, fmt.Errorf("unknown policy %q", name)
+    }
+    out, _, err := prg.ContextEval(ctx, input)
+    if err != nil {
+        return nil, fmt.Errorf("evaluate policy %q: %w", name, err)
+    }
+    return out, nil
+}
+{{/if}}
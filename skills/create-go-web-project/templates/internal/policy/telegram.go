@@ -0,0 +1,32 @@
+{{#if CEL}}
+{{#if TELEGRAM}}
+package policy
+
+import (
+    "context"
+
+    "github.com/google/cel-go/common/types"
+    "github.com/telebot/tele"
+)
+
+// AllowCommand reports whether the "telegram_command" policy permits user u
+// to run command on the bot, driven by the same CEL engine as HTTP access.
+func AllowCommand(c *Compiler, u *tele.User, command string) (bool, error) {
+    input := map[string]any{
+        "user": map[string]any{
+            "id":       u.ID,
+            "username": u.Username,
+        },
+        "request": map[string]any{
+            "command": command,
+        },
+    }
+
+    out, err := c.EvaluateNamed(context.Background(), "telegram_command", input)
+    if err != nil {
+        return false, err
+    }
+    return out == types.True, nil
+}
+{{/if}}
+{{/if}}
@@ -0,0 +1,129 @@
+{{#if R2}}
+// Package storage wraps the R2/S3 client with streaming upload/download
+// helpers built on aws-sdk-go-v2's s3/manager, so large objects don't have
+// to be buffered in memory.
+package storage
+
+import (
+    "context"
+    "fmt"
+    "io"
+
+    "github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+    "github.com/aws/aws-sdk-go-v2/service/s3"
+{{#if OBSERVABILITY}}
+    "go.opentelemetry.io/otel"
+
+    "{{PROJECT_NAME}}/internal/metrics"
+{{/if}}
+)
+
+{{#if OBSERVABILITY}}
+var tracer = otel.Tracer("{{PROJECT_NAME}}/storage")
+{{/if}}
+
+// ProgressFunc is called with the total number of bytes moved so far.
+type ProgressFunc func(bytesTransferred int64)
+
+// UploadResult reports where an object ended up after a successful upload.
+type UploadResult struct {
+    Location string
+    Key      string
+}
+
+// Client streams objects to and from a single R2/S3 bucket, reporting
+// progress to a pluggable callback and aborting in-flight multipart uploads
+// when the calling context is canceled.
+type Client struct {
+    bucket     string
+    uploader   *manager.Uploader
+    downloader *manager.Downloader
+}
+
+// NewClient builds a Client backed by api, uploading in parts of partSize
+// bytes with the given concurrency.
+func NewClient(api *s3.Client, bucket string, partSize int64, concurrency int) *Client {
+    return &Client{
+        bucket: bucket,
+        uploader: manager.NewUploader(api, func(u *manager.Uploader) {
+            u.PartSize = partSize
+            u.Concurrency = concurrency
+        }),
+        downloader: manager.NewDownloader(api, func(d *manager.Downloader) {
+            d.PartSize = partSize
+            d.Concurrency = concurrency
+        }),
+    }
+}
+
+// Upload streams r to key, invoking onProgress (if non-nil) each time the
+// uploader reads more of r. Canceling ctx aborts the in-flight multipart
+// upload.
+func (c *Client) Upload(ctx context.Context, key string, r io.Reader, onProgress ProgressFunc) (*UploadResult, error) {
+{{#if OBSERVABILITY}}
+    ctx, span := tracer.Start(ctx, "storage.upload")
+    defer span.End()
+{{/if}}
+
+    body := r
+    if onProgress != nil {
+        body = &progressReader{r: r, onProgress: onProgress}
+    }
+
+    out, err := c.uploader.Upload(ctx, &s3.PutObjectInput{
+        Bucket: &c.bucket,
+        Key:    &key,
+        Body:   body,
+    })
+{{#if OBSERVABILITY}}
+    metrics.RecordStorageOp("upload", err)
+{{/if}}
+    if err != nil {
+        return nil, fmt.Errorf("upload %s: %w", key, err)
+    }
+
+    return &UploadResult{Location: out.Location, Key: key}, nil
+}
+
+// Download streams key into w, invoking onProgress (if non-nil) once with
+// the total byte count after the download completes; progress is not
+// reported per part.
+func (c *Client) Download(ctx context.Context, key string, w io.WriterAt, onProgress ProgressFunc) (int64, error) {
+{{#if OBSERVABILITY}}
+    ctx, span := tracer.Start(ctx, "storage.download")
+    defer span.End()
+{{/if}}
+
+    n, err := c.downloader.Download(ctx, w, &s3.GetObjectInput{
+        Bucket: &c.bucket,
+        Key:    &key,
+    })
+{{#if OBSERVABILITY}}
+    metrics.RecordStorageOp("download", err)
+{{/if}}
+    if err != nil {
+        return n, fmt.Errorf("download %s: %w", key, err)
+    }
+    if onProgress != nil {
+        onProgress(n)
+    }
+    return n, nil
+}
+
+// progressReader wraps an io.Reader and reports cumulative bytes read after
+// every Read call, similar to cheggaaa/pb's proxy reader.
+type progressReader struct {
+    r          io.Reader
+    onProgress ProgressFunc
+    total      int64
+}
+
+func (p *progressReader) Read(buf []byte) (int, error) {
+    n, err := p.r.Read(buf)
+    if n > 0 {
+        p.total += int64(n)
+        p.onProgress(p.total)
+    }
+    return n, err
+}
+{{/if}}
@@ -0,0 +1,91 @@
+{{#if R2}}
+package storage
+
+import (
+    "bytes"
+    "context"
+    "io"
+    "net/http"
+    "net/http/httptest"
+    "strconv"
+    "sync"
+    "testing"
+
+    "github.com/aws/aws-sdk-go-v2/aws"
+    "github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// fakeS3Server implements just enough of the S3 API (PUT + GET on a single
+// object) to exercise Client.Upload/Download without a real bucket.
+func fakeS3Server(t *testing.T) (*httptest.Server, *sync.Map) {
+    t.Helper()
+    objects := &sync.Map{}
+
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        switch r.Method {
+        case http.MethodPut:
+            body, err := io.ReadAll(r.Body)
+            if err != nil {
+                http.Error(w, err.Error(), http.StatusInternalServerError)
+                return
+            }
+            objects.Store(r.URL.Path, body)
+            w.WriteHeader(http.StatusOK)
+        case http.MethodGet:
+            v, ok := objects.Load(r.URL.Path)
+            if !ok {
+                http.Error(w, "not found", http.StatusNotFound)
+                return
+            }
+            body := v.([]byte)
+            w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+            w.WriteHeader(http.StatusOK)
+            _, _ = w.Write(body)
+        default:
+            http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+        }
+    }))
+
+    return srv, objects
+}
+
+func TestClientUploadDownload(t *testing.T) {
+    srv, _ := fakeS3Server(t)
+    defer srv.Close()
+
+    api := s3.New(s3.Options{
+        BaseEndpoint: aws.String(srv.URL),
+        Region:       "us-east-1",
+        UsePathStyle: true,
+    })
+    client := NewClient(api, "test-bucket", 5*1024*1024, 2)
+
+    want := []byte("hello from the r2 streaming test")
+    var progressed int64
+    result, err := client.Upload(context.Background(), "greeting.txt", bytes.NewReader(want), func(n int64) {
+        progressed = n
+    })
+    if err != nil {
+        t.Fatalf("Upload() error = %v", err)
+    }
+    if progressed != int64(len(want)) {
+        t.Errorf("progress reported %d bytes, want %d", progressed, len(want))
+    }
+    if result.Key != "greeting.txt" {
+        t.Errorf("result.Key = %q, want %q", result.Key, "greeting.txt")
+    }
+
+    got := make([]byte, len(want))
+    buf := aws.NewWriteAtBuffer(got)
+    n, err := client.Download(context.Background(), "greeting.txt", buf, nil)
+    if err != nil {
+        t.Fatalf("Download() error = %v", err)
+    }
+    if n != int64(len(want)) {
+        t.Errorf("downloaded %d bytes, want %d", n, len(want))
+    }
+    if !bytes.Equal(buf.Bytes(), want) {
+        t.Errorf("downloaded content = %q, want %q", buf.Bytes(), want)
+    }
+}
+{{/if}}
@@ -0,0 +1,126 @@
+package main
+
+import (
+{{#if LOGGING}}
+    "context"
+    "os"
+    "os/signal"
+    "syscall"
+
+    "{{PROJECT_NAME}}/internal/logging"
+{{else}}
+    "log"
+    "os"
+{{#if OBSERVABILITY}}
+    "context"
+{{/if}}
+{{/if}}
+    "net/http"
+{{#if OBSERVABILITY}}
+
+    "{{PROJECT_NAME}}/internal/metrics"
+{{/if}}
+
+    "github.com/joho/godotenv"
+)
+
+func main() {
+{{#if LOGGING}}
+    env := os.Getenv("ENV")
+    logger := logging.New(env, 1)
+
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+    defer stop()
+    defer logger.Info().Msg("shutting down")
+
+    if err := godotenv.Load(); err != nil {
+        logger.Info().Msg("no .env file found, relying on process environment")
+    }
+{{else}}
+    if err := godotenv.Load(); err != nil {
+        log.Println("no .env file found, relying on process environment")
+    }
+{{#if OBSERVABILITY}}
+    ctx := context.Background()
+{{/if}}
+{{/if}}
+
+{{#if OBSERVABILITY}}
+    shutdownTracing, err := setupTracing(ctx)
+    if err != nil {
+{{#if LOGGING}}
+        logger.Fatal().Err(err).Msg("setup tracing")
+{{else}}
+        log.Fatalf("setup tracing: %v", err)
+{{/if}}
+    }
+    defer shutdownTracing(ctx)
+
+    metricsAddr := os.Getenv("METRICS_ADDR")
+    if metricsAddr == "" {
+        metricsAddr = ":9090"
+    }
+    go func() {
+        mux := http.NewServeMux()
+        mux.Handle("/metrics", metrics.Handler())
+        mux.Handle("/healthz", metrics.InstrumentHandler("/healthz", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+            w.WriteHeader(http.StatusOK)
+        })))
+        _ = http.ListenAndServe(metricsAddr, mux)
+    }()
+{{/if}}
+
+{{#if POSTGRES}}
+    databaseURL := os.Getenv("DATABASE_URL")
+    if databaseURL == "" {
+{{#if LOGGING}}
+        logger.Fatal().Msg("DATABASE_URL is required")
+{{else}}
+        log.Fatal("DATABASE_URL is required")
+{{/if}}
+    }
+    db, err := newDB(databaseURL)
+{{else}}
+    dataSourceName := os.Getenv("DATABASE_PATH")
+    if dataSourceName == "" {
+        dataSourceName = "{{PROJECT_NAME}}.db"
+    }
+    db, err := newDB(dataSourceName)
+{{/if}}
+    if err != nil {
+{{#if LOGGING}}
+        logger.Fatal().Err(err).Msg("open database")
+{{else}}
+        log.Fatalf("open database: %v", err)
+{{/if}}
+    }
+{{#if LOGGING}}
+    _ = ctx
+{{/if}}
+
+    handler, err := newAppHandler(db)
+    if err != nil {
+{{#if LOGGING}}
+        logger.Fatal().Err(err).Msg("build app handler")
+{{else}}
+        log.Fatalf("build app handler: %v", err)
+{{/if}}
+    }
+
+    appAddr := os.Getenv("APP_ADDR")
+    if appAddr == "" {
+        appAddr = ":8080"
+    }
+{{#if LOGGING}}
+    logger.Info().Str("addr", appAddr).Msg("starting HTTP server")
+{{else}}
+    log.Printf("starting HTTP server on %s", appAddr)
+{{/if}}
+    if err := http.ListenAndServe(appAddr, handler); err != nil {
+{{#if LOGGING}}
+        logger.Fatal().Err(err).Msg("serve http")
+{{else}}
+        log.Fatalf("serve http: %v", err)
+{{/if}}
+    }
+}
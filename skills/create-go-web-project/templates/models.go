@@ -0,0 +1,19 @@
+{{#if POSTGRES}}
+{{#if FEEDS}}
+package main
+
+import "time"
+
+// FeedItem backs internal/feeds.ItemSource when POSTGRES is enabled.
+type FeedItem struct {
+    ID          string `gorm:"primaryKey"`
+    Title       string
+    Link        string
+    Description string
+    Author      string
+    Private     bool
+    Published   time.Time
+    Updated     time.Time
+}
+{{/if}}
+{{/if}}
@@ -0,0 +1,62 @@
+{{#if GRPC}}
+package server
+
+import (
+    "context"
+    "fmt"
+    "net"
+    "net/http"
+
+    "github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+{{#if WEBSOCKET}}
+    "github.com/improbable-eng/grpc-web/go/grpcweb"
+{{/if}}
+    "github.com/soheilhy/cmux"
+    "google.golang.org/grpc"
+    "google.golang.org/grpc/credentials/insecure"
+
+    v1 "{{PROJECT_NAME}}/gen/v1"
+)
+
+// ServeGRPCGateway listens on addr and multiplexes a gRPC server and its
+// grpc-gateway REST mux over a single HTTP/2 port using cmux.
+func ServeGRPCGateway(ctx context.Context, addr string, grpcServer *grpc.Server) error {
+    lis, err := net.Listen("tcp", addr)
+    if err != nil {
+        return fmt.Errorf("listen %s: %w", addr, err)
+    }
+
+    m := cmux.New(lis)
+    grpcListener := m.MatchWithWriters(cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"))
+    httpListener := m.Match(cmux.Any())
+
+    gwMux := runtime.NewServeMux()
+    conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+    if err != nil {
+        return fmt.Errorf("dial gateway target: %w", err)
+    }
+    if err := v1.RegisterServiceHandler(ctx, gwMux, conn); err != nil {
+        return fmt.Errorf("register gateway handler: %w", err)
+    }
+
+{{#if WEBSOCKET}}
+    wrappedGRPC := grpcweb.WrapServer(grpcServer)
+    httpHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if wrappedGRPC.IsGrpcWebRequest(r) {
+            wrappedGRPC.ServeHTTP(w, r)
+            return
+        }
+        gwMux.ServeHTTP(w, r)
+    })
+{{else}}
+    httpHandler := gwMux
+{{/if}}
+
+    httpServer := &http.Server{Handler: httpHandler}
+
+    go func() { _ = grpcServer.Serve(grpcListener) }()
+    go func() { _ = httpServer.Serve(httpListener) }()
+
+    return m.Serve()
+}
+{{/if}}
@@ -0,0 +1,52 @@
+{{#if OBSERVABILITY}}
+package main
+
+import (
+    "context"
+    "fmt"
+    "os"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+    "go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+    "go.opentelemetry.io/otel/sdk/resource"
+    sdktrace "go.opentelemetry.io/otel/sdk/trace"
+    semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// setupTracing installs a global OpenTelemetry tracer provider for
+// {{PROJECT_NAME}} and returns a shutdown func to flush spans on exit. Spans
+// are exported over OTLP/HTTP when OTEL_EXPORTER_OTLP_ENDPOINT is set, and to
+// stdout otherwise so tracing works without a collector in local development.
+func setupTracing(ctx context.Context) (func(context.Context) error, error) {
+    res, err := resource.New(ctx, resource.WithAttributes(
+        semconv.ServiceName("{{PROJECT_NAME}}"),
+    ))
+    if err != nil {
+        return nil, fmt.Errorf("build resource: %w", err)
+    }
+
+    exporter, err := newSpanExporter(ctx)
+    if err != nil {
+        return nil, fmt.Errorf("build span exporter: %w", err)
+    }
+
+    tp := sdktrace.NewTracerProvider(
+        sdktrace.WithBatcher(exporter),
+        sdktrace.WithResource(res),
+    )
+    otel.SetTracerProvider(tp)
+
+    return tp.Shutdown, nil
+}
+
+// newSpanExporter builds an OTLP/HTTP exporter pointed at
+// OTEL_EXPORTER_OTLP_ENDPOINT, falling back to a stdout exporter when it's
+// unset.
+func newSpanExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+    if endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); endpoint != "" {
+        return otlptracehttp.New(ctx)
+    }
+    return stdouttrace.New()
+}
+{{/if}}
@@ -0,0 +1,14 @@
+{{#if POSTGRES}}
+package main
+
+import "time"
+
+// User is the project's starter domain model, migrated automatically via
+// GORM's AutoMigrate, or by migrations/postgres/0001_init.up.sql when
+// POSTGRES_MIGRATE is enabled.
+type User struct {
+    ID        uint      `gorm:"primaryKey"`
+    Email     string    `gorm:"uniqueIndex;not null"`
+    CreatedAt time.Time
+}
+{{/if}}